@@ -1,22 +1,43 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
+	"github.com/0xboji/quant-trading-skill/internal/bench"
+	"github.com/0xboji/quant-trading-skill/internal/mcp"
+	"github.com/0xboji/quant-trading-skill/internal/perf"
 	"github.com/0xboji/quant-trading-skill/internal/search"
+	"github.com/0xboji/quant-trading-skill/internal/search/index"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
 
 var (
-	domain     string
-	maxResults int
-	dataDir    string
-	aiName     string
-	targetDir  string
+	domain          string
+	maxResults      int
+	dataDir         string
+	aiName          string
+	targetDir       string
+	engine          string
+	indexDir        string
+	httpAddr        string
+	periodsPerYear  float64
+	mar             float64
+	jsonOutput      bool
+	benchQueries    string
+	benchEngine     string
+	benchK          int
+	benchIterations int
+	benchOut        string
+	benchBaseline   string
+	benchCandidate  string
+	explainFlag     bool
 )
 
 var rootCmd = &cobra.Command{
@@ -38,7 +59,11 @@ data sources, and common pitfalls.
 Examples:
   quantpro search "order flow crypto"
   quantpro search "stop loss kelly" -d risk
-  quantpro search "rsi bollinger" -d indicator -n 5`,
+  quantpro search "rsi bollinger" -d indicator -n 5
+  quantpro search "\"order flow imbalance\"" --engine bleve
+  quantpro search "kalmn~1" --engine bleve
+  quantpro search "kelly criterion crypto futures" -d all
+  quantpro search "OFI crypto" --explain`,
 	Args: cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		query := strings.Join(args, " ")
@@ -48,16 +73,317 @@ Examples:
 			dataDir = findDataDir()
 		}
 
-		result, err := search.Search(dataDir, query, domain, maxResults)
+		var result *search.Result
+		var err error
+
+		switch {
+		case domain == "all":
+			result, err = search.SearchAll(dataDir, query, maxResults)
+		case engine == "bleve":
+			if domain == "" {
+				domain = search.DetectDomain(query)
+			}
+			result, err = index.Query(findIndexDir(), domain, query, maxResults)
+		case engine == "bm25" || engine == "":
+			result, err = search.Search(dataDir, query, domain, maxResults)
+		default:
+			color.Red("Error: unknown engine %q (expected bm25 or bleve)", engine)
+			os.Exit(1)
+		}
+
 		if err != nil {
 			color.Red("Error: %v", err)
 			os.Exit(1)
 		}
 
 		printResults(result)
+
+		if explainFlag && result.Explain != nil {
+			printExplain(query, result.Explain)
+		}
+	},
+}
+
+func printExplain(query string, explain *search.Explain) {
+	cyan := color.New(color.FgCyan, color.Bold)
+	cyan.Printf("\nExplain: %q expanded to:\n", query)
+	for _, t := range explain.Terms {
+		fmt.Printf("   %-20s weight=%.2f idf=%.4f\n", t.Text, t.Weight, t.IDF)
+	}
+}
+
+var indexCmd = &cobra.Command{
+	Use:   "index",
+	Short: "Manage the Bleve full-text index used by --engine=bleve",
+}
+
+var indexBuildCmd = &cobra.Command{
+	Use:   "build",
+	Short: "Build the Bleve index from the CSV knowledge base",
+	Run: func(cmd *cobra.Command, args []string) {
+		runIndexBuild(index.Build)
+	},
+}
+
+var indexRebuildCmd = &cobra.Command{
+	Use:   "rebuild",
+	Short: "Delete and rebuild the Bleve index from the CSV knowledge base",
+	Run: func(cmd *cobra.Command, args []string) {
+		runIndexBuild(index.Rebuild)
+	},
+}
+
+var benchCmd = &cobra.Command{
+	Use:   "bench",
+	Short: "Benchmark search quality and latency against a labeled query set",
+	Long: `Run a labeled query set (query, expected_domain, expected_ids) against
+one or more search engines and report domain-detection accuracy, MRR,
+nDCG@k, Recall@k, and p50/p95/p99 latency.
+
+Examples:
+  quantpro bench
+  quantpro bench --engine bleve --k 10 --iterations 5
+  quantpro bench --engine bm25 --out bm25.json
+  quantpro bench --baseline bm25.json --candidate bleve.json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if benchBaseline != "" || benchCandidate != "" {
+			runBenchDiff(benchBaseline, benchCandidate)
+			return
+		}
+
+		if dataDir == "" {
+			dataDir = findDataDir()
+		}
+
+		queries, err := bench.LoadQueries(benchQueries)
+		if err != nil {
+			color.Red("Error: %v", err)
+			os.Exit(1)
+		}
+
+		engines, err := resolveBenchEngines(benchEngine)
+		if err != nil {
+			color.Red("Error: %v", err)
+			os.Exit(1)
+		}
+
+		for _, eng := range engines {
+			report, err := bench.Run(eng, dataDir, queries, benchK, benchIterations)
+			if err != nil {
+				color.Red("Error: %v", err)
+				os.Exit(1)
+			}
+			report.RanAt = time.Now()
+
+			printBenchReport(report)
+
+			if benchOut != "" {
+				if err := writeBenchReport(benchOut, report); err != nil {
+					color.Red("Error: %v", err)
+					os.Exit(1)
+				}
+			}
+		}
+	},
+}
+
+func resolveBenchEngines(name string) ([]bench.Engine, error) {
+	switch name {
+	case "bm25":
+		return []bench.Engine{bench.BM25Engine{}}, nil
+	case "bleve":
+		return []bench.Engine{bench.BleveEngine{IndexDir: findIndexDir()}}, nil
+	case "both", "":
+		return []bench.Engine{bench.BM25Engine{}, bench.BleveEngine{IndexDir: findIndexDir()}}, nil
+	default:
+		return nil, fmt.Errorf("unknown engine %q (expected bm25, bleve, or both)", name)
+	}
+}
+
+func printBenchReport(r *bench.Report) {
+	cyan := color.New(color.FgCyan, color.Bold)
+	yellow := color.New(color.FgYellow)
+
+	cyan.Printf("\n%s\n", strings.Repeat("=", 100))
+	cyan.Printf("BENCH: engine=%s queries=%d k=%d iterations=%d\n", r.Engine, r.Queries, r.K, r.Iterations)
+	cyan.Printf("%s\n\n", strings.Repeat("=", 100))
+
+	row := func(label string, value string) {
+		yellow.Printf("%-20s", label)
+		fmt.Printf("%s\n", value)
+	}
+
+	row("Domain Accuracy", fmt.Sprintf("%.2f%%", r.Metrics.DomainAccuracy*100))
+	row("MRR", fmt.Sprintf("%.4f", r.Metrics.MRR))
+	row("nDCG@k", fmt.Sprintf("%.4f", r.Metrics.NDCGAtK))
+	row("Recall@k", fmt.Sprintf("%.4f", r.Metrics.RecallAtK))
+	row("p50 latency", fmt.Sprintf("%.3f ms", r.Metrics.P50Ms))
+	row("p95 latency", fmt.Sprintf("%.3f ms", r.Metrics.P95Ms))
+	row("p99 latency", fmt.Sprintf("%.3f ms", r.Metrics.P99Ms))
+	fmt.Println()
+}
+
+func writeBenchReport(path string, r *bench.Report) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+func runBenchDiff(baselinePath, candidatePath string) {
+	if baselinePath == "" || candidatePath == "" {
+		color.Red("Error: --baseline and --candidate must be passed together")
+		os.Exit(1)
+	}
+
+	baseline, err := readBenchReport(baselinePath)
+	if err != nil {
+		color.Red("Error: %v", err)
+		os.Exit(1)
+	}
+	candidate, err := readBenchReport(candidatePath)
+	if err != nil {
+		color.Red("Error: %v", err)
+		os.Exit(1)
+	}
+
+	cyan := color.New(color.FgCyan, color.Bold)
+	green := color.New(color.FgGreen)
+	red := color.New(color.FgRed)
+
+	cyan.Printf("\n%s\n", strings.Repeat("=", 100))
+	cyan.Printf("BENCH DIFF: %s (baseline) vs %s (candidate)\n", baseline.Engine, candidate.Engine)
+	cyan.Printf("%s\n\n", strings.Repeat("=", 100))
+
+	fmt.Printf("%-16s %10s %10s %9s\n", "Metric", "Baseline", "Candidate", "Delta")
+	for _, row := range bench.Diff(baseline, candidate) {
+		if row.Delta < 0 {
+			red.Println(row.String())
+		} else {
+			green.Println(row.String())
+		}
+	}
+
+	if regressions := bench.Regressions(bench.Diff(baseline, candidate)); len(regressions) > 0 {
+		red.Printf("\n%d metric(s) regressed\n", len(regressions))
+		os.Exit(1)
+	}
+}
+
+func readBenchReport(path string) (*bench.Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var r bench.Report
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &r, nil
+}
+
+var evaluateCmd = &cobra.Command{
+	Use:   "evaluate <trades.csv>",
+	Short: "Compute trade-performance statistics for a fills or equity CSV",
+	Long: `Compute the trade statistics the knowledge base talks about but never
+calculates: return, Sharpe, Sortino, Calmar, max drawdown, win rate,
+profit factor, average win/loss, expectancy, and turnover.
+
+Accepts two input shapes, auto-detected from the CSV header:
+  - a per-trade fill log: time,symbol,side,qty,price,fee
+  - a periodic equity or returns series: time,equity or time,return
+
+Examples:
+  quantpro evaluate trades.csv
+  quantpro evaluate equity.csv --periods-per-year 252
+  quantpro evaluate trades.csv --json`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		opts := perf.Options{PeriodsPerYear: periodsPerYear, MAR: mar}
+		stats, err := perf.Evaluate(args[0], opts)
+		if err != nil {
+			color.Red("Error: %v", err)
+			os.Exit(1)
+		}
+
+		if dataDir == "" {
+			dataDir = findDataDir()
+		}
+
+		if jsonOutput {
+			printEvaluateJSON(stats)
+		} else {
+			printEvaluateTable(stats)
+		}
+
+		printFollowUps(stats, dataDir)
 	},
 }
 
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the knowledge base over JSON-RPC 2.0 (MCP) for AI-agent integration",
+	Long: `Expose the knowledge base over stdio JSON-RPC 2.0 in the shape Model
+Context Protocol clients expect, so agents can call it as a tool instead
+of shelling out to the CLI. Methods: search, list_domains, get_entry,
+detect_domain, tools/list.
+
+Examples:
+  quantpro serve
+  quantpro serve --http :7777`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if dataDir == "" {
+			dataDir = findDataDir()
+		}
+		server := mcp.NewServer(dataDir)
+
+		if httpAddr != "" {
+			color.Cyan("QuantPro MCP server listening on %s\n", httpAddr)
+			if err := http.ListenAndServe(httpAddr, server); err != nil {
+				color.Red("Error: %v", err)
+				os.Exit(1)
+			}
+			return
+		}
+
+		if err := server.ServeStdio(os.Stdin, os.Stdout); err != nil {
+			color.Red("Error: %v", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func runIndexBuild(fn func(index.BuildOptions) error) {
+	if dataDir == "" {
+		dataDir = findDataDir()
+	}
+
+	opts := index.BuildOptions{
+		DataDir:  dataDir,
+		IndexDir: findIndexDir(),
+		Domain:   domain,
+	}
+
+	if err := fn(opts); err != nil {
+		color.Red("Error: %v", err)
+		os.Exit(1)
+	}
+
+	color.Green("Index built at %s\n", opts.IndexDir)
+}
+
+func findIndexDir() string {
+	if indexDir != "" {
+		return indexDir
+	}
+	dir := dataDir
+	if dir == "" {
+		dir = findDataDir()
+	}
+	return filepath.Join(dir, ".index")
+}
+
 var initCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Initialize QuantPro skill in your project",
@@ -90,14 +416,48 @@ func init() {
 	searchCmd.Flags().StringVarP(&domain, "domain", "d", "", "Domain to search (strategy, indicator, risk, data, anti-pattern)")
 	searchCmd.Flags().IntVarP(&maxResults, "max-results", "n", 3, "Maximum number of results")
 	searchCmd.Flags().StringVar(&dataDir, "data-dir", "", "Path to data directory")
+	searchCmd.Flags().StringVar(&engine, "engine", "bm25", "Search engine to use (bm25, bleve)")
+	searchCmd.Flags().BoolVar(&explainFlag, "explain", false, "Print the expanded query and per-term IDF contributions (bm25 engine only)")
 
 	// Init command flags
 	initCmd.Flags().StringVar(&aiName, "ai", "", "AI agent name (required)")
 	initCmd.Flags().StringVar(&targetDir, "dir", ".", "Target directory (default: current)")
 	initCmd.MarkFlagRequired("ai")
 
+	// Index command flags
+	indexCmd.PersistentFlags().StringVar(&dataDir, "data-dir", "", "Path to data directory")
+	indexCmd.PersistentFlags().StringVar(&indexDir, "index-dir", "", "Path to store the Bleve index (default: <data-dir>/.index)")
+	indexCmd.PersistentFlags().StringVarP(&domain, "domain", "d", "", "Domain to index (default: all domains)")
+	indexCmd.AddCommand(indexBuildCmd)
+	indexCmd.AddCommand(indexRebuildCmd)
+
+	// Serve command flags
+	serveCmd.Flags().StringVar(&dataDir, "data-dir", "", "Path to data directory")
+	serveCmd.Flags().StringVar(&httpAddr, "http", "", "Serve HTTP JSON-RPC on this address instead of stdio (e.g. :7777)")
+
+	// Evaluate command flags
+	evaluateCmd.Flags().Float64Var(&periodsPerYear, "periods-per-year", 252, "Periods per year used to annualize return/Sharpe/Sortino")
+	evaluateCmd.Flags().Float64Var(&mar, "mar", 0, "Minimum acceptable return (Sortino downside threshold)")
+	evaluateCmd.Flags().BoolVar(&jsonOutput, "json", false, "Emit results as JSON instead of a table")
+	evaluateCmd.Flags().StringVar(&dataDir, "data-dir", "", "Path to data directory (for cross-linked knowledge-base follow-ups)")
+
+	// Bench command flags
+	benchCmd.Flags().StringVar(&dataDir, "data-dir", "", "Path to data directory")
+	benchCmd.Flags().StringVar(&indexDir, "index-dir", "", "Path to the Bleve index (default: <data-dir>/.index)")
+	benchCmd.Flags().StringVar(&benchQueries, "queries", "bench/queries.yaml", "Path to the labeled query set (YAML or JSON)")
+	benchCmd.Flags().StringVar(&benchEngine, "engine", "both", "Engine(s) to benchmark (bm25, bleve, both)")
+	benchCmd.Flags().IntVar(&benchK, "k", 5, "Cutoff k for nDCG@k and Recall@k")
+	benchCmd.Flags().IntVar(&benchIterations, "iterations", 3, "Iterations over the query set, for latency percentiles")
+	benchCmd.Flags().StringVar(&benchOut, "out", "", "Write the report as JSON to this path")
+	benchCmd.Flags().StringVar(&benchBaseline, "baseline", "", "Baseline report JSON to diff against --candidate")
+	benchCmd.Flags().StringVar(&benchCandidate, "candidate", "", "Candidate report JSON to diff against --baseline")
+
 	rootCmd.AddCommand(searchCmd)
 	rootCmd.AddCommand(initCmd)
+	rootCmd.AddCommand(indexCmd)
+	rootCmd.AddCommand(serveCmd)
+	rootCmd.AddCommand(evaluateCmd)
+	rootCmd.AddCommand(benchCmd)
 }
 
 func findDataDir() string {
@@ -176,6 +536,16 @@ func initializeSkill(targetDir, aiName string) error {
 	}
 	green.Printf("‚úÖ Copied: 5 CSV files (122 knowledge entries)\n")
 
+	// Copy the synonym map alongside the CSVs, if present, so expanded
+	// search keeps working after install.
+	synonymsSrc := filepath.Join(sourceDataDir, "synonyms.yaml")
+	if _, err := os.Stat(synonymsSrc); err == nil {
+		if err := copyFile(synonymsSrc, filepath.Join(dataDestDir, "synonyms.yaml")); err != nil {
+			return fmt.Errorf("failed to copy synonyms.yaml: %w", err)
+		}
+		green.Printf("‚úÖ Copied: synonyms.yaml\n")
+	}
+
 	// Create workflow file
 	workflowPath := filepath.Join(agentDir, "use-quant-skill.md")
 	workflowContent := generateWorkflowContent(aiName)
@@ -285,10 +655,20 @@ quantpro search "hft mistakes" -d anti-pattern
 quantpro search "backtesting overfitting" -d anti-pattern
 `+"```"+`
 
+## MCP Server Mode
+
+Instead of shelling out per query, %s can run QuantPro as a long-lived
+JSON-RPC 2.0 (MCP-shaped) server and call its "search" tool directly:
+
+`+"```bash"+`
+quantpro serve              # stdio JSON-RPC
+quantpro serve --http :7777 # HTTP JSON-RPC
+`+"```"+`
+
 ## Documentation
 
 See .shared/quant-trading-pro/SKILL.md for complete documentation.
-`, aiName)
+`, aiName, aiName)
 }
 
 func generateSkillDoc() string {
@@ -371,26 +751,20 @@ func printResults(result *search.Result) {
 	for i, r := range result.Results {
 		green.Printf("%d. ", i+1)
 
-		var primaryField string
-		switch result.Domain {
-		case "strategy":
-			primaryField = r["Strategy Name"]
-		case "indicator":
-			primaryField = r["Indicator Name"]
-		case "risk":
-			primaryField = r["Risk Control"]
-		case "data":
-			primaryField = r["Data Type"]
-		case "anti-pattern":
-			primaryField = r["Issue"]
-		default:
-			primaryField = "Unknown"
+		rowDomain := result.Domain
+		if result.Domains != nil {
+			rowDomain = result.Domains[i]
 		}
 
-		fmt.Printf("%s\n", primaryField)
+		primaryField := primaryFieldFor(rowDomain, r)
+		if result.Domains != nil {
+			fmt.Printf("[%s] %s\n", rowDomain, primaryField)
+		} else {
+			fmt.Printf("%s\n", primaryField)
+		}
 
 		fieldCount := 0
-		for _, key := range getOrderedKeys(result.Domain) {
+		for _, key := range getOrderedKeys(rowDomain) {
 			if fieldCount >= 4 {
 				break
 			}
@@ -405,6 +779,90 @@ func printResults(result *search.Result) {
 	color.Cyan("\nTip: Use -d flag to specify domain, -n flag to get more results\n")
 }
 
+func printEvaluateJSON(stats *perf.Stats) {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(stats)
+}
+
+func printEvaluateTable(stats *perf.Stats) {
+	cyan := color.New(color.FgCyan, color.Bold)
+	yellow := color.New(color.FgYellow)
+
+	cyan.Printf("\n%s\n", strings.Repeat("=", 100))
+	cyan.Printf("TRADE PERFORMANCE\n")
+	cyan.Printf("%s\n\n", strings.Repeat("=", 100))
+
+	row := func(label string, value string) {
+		yellow.Printf("%-28s", label)
+		fmt.Printf("%s\n", value)
+	}
+
+	row("Total Return", fmt.Sprintf("%.2f%%", stats.TotalReturn*100))
+	row("Annualized Return", fmt.Sprintf("%.2f%%", stats.AnnualizedReturn*100))
+	row("Sharpe", fmt.Sprintf("%.2f", stats.Sharpe))
+	row("Sortino", fmt.Sprintf("%.2f", stats.Sortino))
+	row("Calmar", fmt.Sprintf("%.2f", stats.Calmar))
+	row("Max Drawdown", fmt.Sprintf("%.2f%% (%d periods)", stats.MaxDrawdown*100, stats.MaxDrawdownDuration))
+	row("Win Rate", fmt.Sprintf("%.2f%%", stats.WinRate*100))
+	row("Profit Factor", fmt.Sprintf("%.2f", stats.ProfitFactor))
+	row("Avg Win / Avg Loss", fmt.Sprintf("%.2f%% / %.2f%%", stats.AvgWin*100, stats.AvgLoss*100))
+	row("Expectancy", fmt.Sprintf("%.2f%%", stats.Expectancy*100))
+	row("Turnover", fmt.Sprintf("%.2f", stats.Turnover))
+	row("Trade Count", fmt.Sprintf("%d", stats.TradeCount))
+	fmt.Println()
+}
+
+// followUpRule cross-links a breached metric threshold to a knowledge
+// base query, so a user goes straight from "here's what's wrong" to
+// "here's how to fix it".
+type followUpRule struct {
+	label  string
+	breach func(*perf.Stats) bool
+	query  string
+	domain string
+}
+
+var followUpRules = []followUpRule{
+	{
+		label:  "Max drawdown",
+		breach: func(s *perf.Stats) bool { return s.MaxDrawdown <= -0.20 },
+		query:  "drawdown control",
+		domain: "risk",
+	},
+	{
+		label:  "Profit factor",
+		breach: func(s *perf.Stats) bool { return s.TradeCount > 0 && s.ProfitFactor < 1 },
+		query:  "backtesting overfitting",
+		domain: "anti-pattern",
+	},
+}
+
+// printFollowUps runs the knowledge base against any breached metric so
+// the CLI closes the loop between "measure my strategy" and "look up how
+// to fix it".
+func printFollowUps(stats *perf.Stats, dataDir string) {
+	cyan := color.New(color.FgCyan, color.Bold)
+
+	for _, rule := range followUpRules {
+		if !rule.breach(stats) {
+			continue
+		}
+
+		result, err := search.Search(dataDir, rule.query, rule.domain, 1)
+		if err != nil || result.Count == 0 {
+			continue
+		}
+
+		cyan.Printf("\n%s breach -> suggested reading (quantpro search %q -d %s):\n", rule.label, rule.query, rule.domain)
+		printResults(result)
+	}
+}
+
+func primaryFieldFor(domain string, r map[string]string) string {
+	return search.PrimaryField(domain, r)
+}
+
 func getOrderedKeys(domain string) []string {
 	switch domain {
 	case "strategy":
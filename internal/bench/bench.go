@@ -0,0 +1,38 @@
+// Package bench is the search-quality benchmark harness behind
+// `quantpro bench`. It runs a labeled query set against a search engine
+// and reports domain-detection accuracy, ranking quality (MRR, nDCG@k,
+// Recall@k), and latency percentiles, so BM25, Bleve, and future rankers
+// can be compared apples-to-apples.
+package bench
+
+import "time"
+
+// LabeledQuery is one entry in a bench query set: a query plus the
+// ground truth it's judged against.
+type LabeledQuery struct {
+	Query          string   `yaml:"query" json:"query"`
+	ExpectedDomain string   `yaml:"expected_domain" json:"expected_domain"`
+	ExpectedIDs    []string `yaml:"expected_ids" json:"expected_ids"`
+}
+
+// Metrics summarizes one engine's performance over a query set.
+type Metrics struct {
+	DomainAccuracy float64 `json:"domain_accuracy"`
+	MRR            float64 `json:"mrr"`
+	NDCGAtK        float64 `json:"ndcg_at_k"`
+	RecallAtK      float64 `json:"recall_at_k"`
+	P50Ms          float64 `json:"p50_ms"`
+	P95Ms          float64 `json:"p95_ms"`
+	P99Ms          float64 `json:"p99_ms"`
+}
+
+// Report is the JSON-serializable result of one bench run, suitable for
+// saving with --out and later comparing with --baseline/--candidate.
+type Report struct {
+	Engine     string    `json:"engine"`
+	K          int       `json:"k"`
+	Iterations int       `json:"iterations"`
+	Queries    int       `json:"queries"`
+	Metrics    Metrics   `json:"metrics"`
+	RanAt      time.Time `json:"ran_at"`
+}
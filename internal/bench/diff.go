@@ -0,0 +1,55 @@
+package bench
+
+import "fmt"
+
+// DiffRow is one metric's baseline vs. candidate comparison.
+type DiffRow struct {
+	Metric    string
+	Baseline  float64
+	Candidate float64
+	Delta     float64
+}
+
+// Diff compares two reports metric-by-metric. Positive deltas mean the
+// candidate improved. For latency rows, where lower is better, the sign is
+// flipped so a positive delta still reads as "improvement" and a negative
+// delta always reads as "regression".
+func Diff(baseline, candidate *Report) []DiffRow {
+	higherIsBetter := func(name string, b, c float64) DiffRow {
+		return DiffRow{Metric: name, Baseline: b, Candidate: c, Delta: c - b}
+	}
+	lowerIsBetter := func(name string, b, c float64) DiffRow {
+		return DiffRow{Metric: name, Baseline: b, Candidate: c, Delta: b - c}
+	}
+
+	bm, cm := baseline.Metrics, candidate.Metrics
+	return []DiffRow{
+		higherIsBetter("Domain Accuracy", bm.DomainAccuracy, cm.DomainAccuracy),
+		higherIsBetter("MRR", bm.MRR, cm.MRR),
+		higherIsBetter("nDCG@k", bm.NDCGAtK, cm.NDCGAtK),
+		higherIsBetter("Recall@k", bm.RecallAtK, cm.RecallAtK),
+		lowerIsBetter("p50 (ms)", bm.P50Ms, cm.P50Ms),
+		lowerIsBetter("p95 (ms)", bm.P95Ms, cm.P95Ms),
+		lowerIsBetter("p99 (ms)", bm.P99Ms, cm.P99Ms),
+	}
+}
+
+// Regressions returns the rows from Diff where the candidate got worse.
+func Regressions(rows []DiffRow) []DiffRow {
+	var out []DiffRow
+	for _, r := range rows {
+		if r.Delta < 0 {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// String renders a DiffRow as a single aligned table line.
+func (r DiffRow) String() string {
+	sign := "+"
+	if r.Delta < 0 {
+		sign = ""
+	}
+	return fmt.Sprintf("%-16s %10.4f %10.4f %8s%.4f", r.Metric, r.Baseline, r.Candidate, sign, r.Delta)
+}
@@ -0,0 +1,34 @@
+package bench
+
+import (
+	"github.com/0xboji/quant-trading-skill/internal/search"
+	"github.com/0xboji/quant-trading-skill/internal/search/index"
+)
+
+// Engine is the common interface bench compares across search backends.
+// Domain is pre-resolved by the caller (via search.DetectDomain) so
+// ranking quality and domain-detection accuracy are measured separately.
+type Engine interface {
+	Name() string
+	Search(dataDir, query, domain string, maxResults int) (*search.Result, error)
+}
+
+// BM25Engine runs queries through the in-memory BM25 ranker.
+type BM25Engine struct{}
+
+func (BM25Engine) Name() string { return "bm25" }
+
+func (BM25Engine) Search(dataDir, query, domain string, maxResults int) (*search.Result, error) {
+	return search.Search(dataDir, query, domain, maxResults)
+}
+
+// BleveEngine runs queries through a pre-built Bleve index.
+type BleveEngine struct {
+	IndexDir string
+}
+
+func (BleveEngine) Name() string { return "bleve" }
+
+func (e BleveEngine) Search(dataDir, query, domain string, maxResults int) (*search.Result, error) {
+	return index.Query(e.IndexDir, domain, query, maxResults)
+}
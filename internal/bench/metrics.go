@@ -0,0 +1,92 @@
+package bench
+
+import (
+	"math"
+	"sort"
+)
+
+// reciprocalRank returns 1/rank of the first retrieved ID that appears in
+// expected (1-indexed), or 0 if none of expected was retrieved.
+func reciprocalRank(retrieved []string, expected []string) float64 {
+	want := toSet(expected)
+	for i, id := range retrieved {
+		if want[id] {
+			return 1.0 / float64(i+1)
+		}
+	}
+	return 0
+}
+
+// ndcgAtK scores retrieved[:k] against expected using binary relevance:
+// a retrieved ID either is or isn't in expected.
+func ndcgAtK(retrieved []string, expected []string, k int) float64 {
+	want := toSet(expected)
+
+	dcg := 0.0
+	for i, id := range truncate(retrieved, k) {
+		if want[id] {
+			dcg += 1.0 / math.Log2(float64(i+2)) // i+2 so position 0 -> log2(2)=1
+		}
+	}
+
+	idealHits := len(expected)
+	if idealHits > k {
+		idealHits = k
+	}
+	idcg := 0.0
+	for i := 0; i < idealHits; i++ {
+		idcg += 1.0 / math.Log2(float64(i+2))
+	}
+	if idcg == 0 {
+		return 0
+	}
+	return dcg / idcg
+}
+
+// recallAtK is the fraction of expected IDs present in retrieved[:k].
+func recallAtK(retrieved []string, expected []string, k int) float64 {
+	if len(expected) == 0 {
+		return 0
+	}
+	want := toSet(expected)
+	hits := 0
+	for _, id := range truncate(retrieved, k) {
+		if want[id] {
+			hits++
+		}
+	}
+	return float64(hits) / float64(len(expected))
+}
+
+func toSet(ids []string) map[string]bool {
+	m := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		m[id] = true
+	}
+	return m
+}
+
+func truncate(ids []string, k int) []string {
+	if len(ids) > k {
+		return ids[:k]
+	}
+	return ids
+}
+
+// percentile returns the pth percentile (0-100) of samples using
+// the nearest-rank method (no interpolation between samples). samples is
+// sorted in place.
+func percentile(samples []float64, p float64) float64 {
+	if len(samples) == 0 {
+		return 0
+	}
+	sort.Float64s(samples)
+	rank := int(math.Ceil(p/100*float64(len(samples)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(samples) {
+		rank = len(samples) - 1
+	}
+	return samples[rank]
+}
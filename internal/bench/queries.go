@@ -0,0 +1,35 @@
+package bench
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadQueries reads a labeled query set from YAML or JSON, chosen by the
+// file extension (.yaml/.yml vs .json).
+func LoadQueries(path string) ([]LabeledQuery, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var queries []LabeledQuery
+	if strings.HasSuffix(path, ".json") {
+		err = json.Unmarshal(data, &queries)
+	} else {
+		err = yaml.Unmarshal(data, &queries)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse query set %s: %w", path, err)
+	}
+
+	if len(queries) == 0 {
+		return nil, fmt.Errorf("query set %s has no entries", path)
+	}
+
+	return queries, nil
+}
@@ -0,0 +1,78 @@
+package bench
+
+import (
+	"time"
+
+	"github.com/0xboji/quant-trading-skill/internal/search"
+)
+
+// Run executes queries against engine, repeating the full set
+// iterations times to collect a meaningful latency sample, and returns
+// the averaged quality metrics alongside latency percentiles.
+//
+// Ranking quality (MRR, nDCG@k, Recall@k) is measured once per query
+// using the domain the engine actually searched, so a wrong domain
+// detection is reflected as a retrieval miss rather than skipped.
+func Run(engine Engine, dataDir string, queries []LabeledQuery, k, iterations int) (*Report, error) {
+	if iterations <= 0 {
+		iterations = 1
+	}
+	if k <= 0 {
+		k = 5
+	}
+
+	var (
+		domainHits int
+		mrrSum     float64
+		ndcgSum    float64
+		recallSum  float64
+		latencies  []float64
+	)
+
+	for iter := 0; iter < iterations; iter++ {
+		for _, lq := range queries {
+			detected := search.DetectDomain(lq.Query)
+
+			start := time.Now()
+			result, err := engine.Search(dataDir, lq.Query, detected, k)
+			elapsed := time.Since(start)
+			latencies = append(latencies, float64(elapsed.Microseconds())/1000.0)
+
+			if iter == 0 && detected == lq.ExpectedDomain {
+				domainHits++
+			}
+			if iter > 0 {
+				continue // quality metrics only need to be computed once
+			}
+
+			var retrieved []string
+			if err == nil && result != nil {
+				for _, row := range result.Results {
+					retrieved = append(retrieved, search.PrimaryField(detected, row))
+				}
+			}
+
+			mrrSum += reciprocalRank(retrieved, lq.ExpectedIDs)
+			ndcgSum += ndcgAtK(retrieved, lq.ExpectedIDs, k)
+			recallSum += recallAtK(retrieved, lq.ExpectedIDs, k)
+		}
+	}
+
+	n := float64(len(queries))
+
+	return &Report{
+		Engine:     engine.Name(),
+		K:          k,
+		Iterations: iterations,
+		Queries:    len(queries),
+		Metrics: Metrics{
+			DomainAccuracy: float64(domainHits) / n,
+			MRR:            mrrSum / n,
+			NDCGAtK:        ndcgSum / n,
+			RecallAtK:      recallSum / n,
+			P50Ms:          percentile(latencies, 50),
+			P95Ms:          percentile(latencies, 95),
+			P99Ms:          percentile(latencies, 99),
+		},
+	}, nil
+}
@@ -29,7 +29,15 @@ func New(k1, b float64) *BM25 {
 }
 
 // Tokenize converts text to tokens (lowercase, alphanumeric, >2 chars)
-func (bm *BM25) Tokenize(text string) []string {
+func Tokenize(text string) []string {
+	return TokenizeMinLen(text, 3)
+}
+
+// TokenizeMinLen is Tokenize with a configurable minimum token length.
+// Callers that need tokens shorter than BM25's own 3-char floor (e.g.
+// internal/search/expand matching 2-char abbreviations against a synonym
+// map) can lower minLen without changing corpus/query tokenization.
+func TokenizeMinLen(text string, minLen int) []string {
 	// Remove punctuation, convert to lowercase
 	re := regexp.MustCompile(`[^\w\s]`)
 	text = re.ReplaceAllString(strings.ToLower(text), " ")
@@ -38,13 +46,25 @@ func (bm *BM25) Tokenize(text string) []string {
 	words := strings.Fields(text)
 	tokens := make([]string, 0, len(words))
 	for _, w := range words {
-		if len(w) > 2 {
+		if len(w) >= minLen {
 			tokens = append(tokens, w)
 		}
 	}
 	return tokens
 }
 
+// Tokenize converts text to tokens using the package-level Tokenize,
+// kept as a method for callers already holding a *BM25.
+func (bm *BM25) Tokenize(text string) []string {
+	return Tokenize(text)
+}
+
+// IDF returns the inverse document frequency learned for term by Fit, or
+// 0 if term never appeared in the corpus.
+func (bm *BM25) IDF(term string) float64 {
+	return bm.idf[term]
+}
+
 // Fit builds the BM25 index from documents
 func (bm *BM25) Fit(documents []string) {
 	bm.N = len(documents)
@@ -119,3 +139,35 @@ func (bm *BM25) Score(query string) []Result {
 
 	return results
 }
+
+// ScoreWeighted scores all documents against a pre-tokenized, weighted
+// query, multiplying each term's IDF contribution by its weight. This is
+// how query expansion (internal/search/expand) folds synonym terms in at
+// a lower weight than the original tokens without re-tokenizing a
+// synthetic query string.
+func (bm *BM25) ScoreWeighted(terms map[string]float64) []Result {
+	results := make([]Result, bm.N)
+
+	for idx, doc := range bm.corpus {
+		score := 0.0
+		docLen := float64(bm.docLengths[idx])
+
+		termFreqs := make(map[string]int)
+		for _, word := range doc {
+			termFreqs[word]++
+		}
+
+		for token, weight := range terms {
+			if idf, ok := bm.idf[token]; ok {
+				tf := float64(termFreqs[token])
+				numerator := tf * (bm.k1 + 1.0)
+				denominator := tf + bm.k1*(1.0-bm.b+bm.b*docLen/bm.avgdl)
+				score += weight * idf * numerator / denominator
+			}
+		}
+
+		results[idx] = Result{Index: idx, Score: score}
+	}
+
+	return results
+}
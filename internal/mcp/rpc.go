@@ -0,0 +1,55 @@
+// Package mcp exposes the QuantPro knowledge base over JSON-RPC 2.0 in
+// the shape expected by the Model Context Protocol, so agents can call
+// it as a tool over stdio or HTTP instead of shelling out to the CLI.
+package mcp
+
+import "encoding/json"
+
+// JSON-RPC 2.0 standard error codes. Knowledge-base failures are reported
+// as CodeInvalidParams or CodeInternalError.
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+)
+
+// Request is a single JSON-RPC 2.0 call.
+type Request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// Response is a single JSON-RPC 2.0 reply. Exactly one of Result/Error is
+// set, matching the spec.
+type Response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// Error is a JSON-RPC 2.0 error object.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func errorResponse(id json.RawMessage, code int, message string) Response {
+	return Response{
+		JSONRPC: "2.0",
+		ID:      id,
+		Error:   &Error{Code: code, Message: message},
+	}
+}
+
+func resultResponse(id json.RawMessage, result interface{}) Response {
+	return Response{
+		JSONRPC: "2.0",
+		ID:      id,
+		Result:  result,
+	}
+}
@@ -0,0 +1,231 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/0xboji/quant-trading-skill/internal/search"
+)
+
+// Server dispatches JSON-RPC requests against the CSV knowledge base
+// rooted at DataDir, reusing internal/search unchanged.
+type Server struct {
+	DataDir string
+}
+
+// NewServer creates a Server rooted at dataDir.
+func NewServer(dataDir string) *Server {
+	return &Server{DataDir: dataDir}
+}
+
+// Handle dispatches a single JSON-RPC request and returns its response.
+// Notifications (requests with no ID) still receive a Response value;
+// callers serving stdio/HTTP transports decide whether to write it.
+func (s *Server) Handle(req Request) Response {
+	if req.JSONRPC != "" && req.JSONRPC != "2.0" {
+		return errorResponse(req.ID, CodeInvalidRequest, "jsonrpc must be \"2.0\"")
+	}
+
+	switch req.Method {
+	case "initialize":
+		return s.handleInitialize(req)
+	case "search":
+		return s.handleSearch(req)
+	case "list_domains":
+		return s.handleListDomains(req)
+	case "get_entry":
+		return s.handleGetEntry(req)
+	case "detect_domain":
+		return s.handleDetectDomain(req)
+	case "tools/list":
+		return s.handleToolsList(req)
+	case "tools/call":
+		return s.handleToolsCall(req)
+	default:
+		return errorResponse(req.ID, CodeMethodNotFound, fmt.Sprintf("unknown method: %s", req.Method))
+	}
+}
+
+// mcpProtocolVersion is the Model Context Protocol revision QuantPro
+// implements, echoed back from initialize so clients can negotiate.
+const mcpProtocolVersion = "2024-11-05"
+
+// handleInitialize answers the MCP handshake every client is required to
+// perform before calling tools/list or search: without it, a real MCP
+// client never gets past MethodNotFound.
+func (s *Server) handleInitialize(req Request) Response {
+	return resultResponse(req.ID, map[string]interface{}{
+		"protocolVersion": mcpProtocolVersion,
+		"capabilities": map[string]interface{}{
+			"tools": map[string]interface{}{},
+		},
+		"serverInfo": map[string]interface{}{
+			"name":    "quantpro",
+			"version": "1.0.0",
+		},
+	})
+}
+
+type searchParams struct {
+	Query      string `json:"query"`
+	Domain     string `json:"domain"`
+	MaxResults int    `json:"max_results"`
+}
+
+func (s *Server) handleSearch(req Request) Response {
+	var p searchParams
+	if err := json.Unmarshal(req.Params, &p); err != nil {
+		return errorResponse(req.ID, CodeInvalidParams, err.Error())
+	}
+	if p.Query == "" {
+		return errorResponse(req.ID, CodeInvalidParams, "query is required")
+	}
+	if p.MaxResults <= 0 {
+		p.MaxResults = 3
+	}
+
+	var result *search.Result
+	var err error
+	if p.Domain == "all" {
+		result, err = search.SearchAll(s.DataDir, p.Query, p.MaxResults)
+	} else {
+		result, err = search.Search(s.DataDir, p.Query, p.Domain, p.MaxResults)
+	}
+	if err != nil {
+		return errorResponse(req.ID, CodeInternalError, err.Error())
+	}
+
+	return resultResponse(req.ID, result)
+}
+
+func (s *Server) handleListDomains(req Request) Response {
+	type domainInfo struct {
+		Domain     string   `json:"domain"`
+		File       string   `json:"file"`
+		OutputCols []string `json:"output_cols"`
+	}
+
+	domains := make([]domainInfo, 0, len(search.DomainConfigs))
+	for domain, config := range search.DomainConfigs {
+		domains = append(domains, domainInfo{
+			Domain:     domain,
+			File:       config.File,
+			OutputCols: config.OutputCols,
+		})
+	}
+
+	return resultResponse(req.ID, domains)
+}
+
+type getEntryParams struct {
+	Domain string `json:"domain"`
+	ID     int    `json:"id"`
+}
+
+func (s *Server) handleGetEntry(req Request) Response {
+	var p getEntryParams
+	if err := json.Unmarshal(req.Params, &p); err != nil {
+		return errorResponse(req.ID, CodeInvalidParams, err.Error())
+	}
+
+	rows, _, err := search.LoadDomainData(s.DataDir, p.Domain)
+	if err != nil {
+		return errorResponse(req.ID, CodeInvalidParams, err.Error())
+	}
+	if p.ID < 0 || p.ID >= len(rows) {
+		return errorResponse(req.ID, CodeInvalidParams, fmt.Sprintf("id %d out of range for domain %s (%d entries)", p.ID, p.Domain, len(rows)))
+	}
+
+	return resultResponse(req.ID, rows[p.ID])
+}
+
+type detectDomainParams struct {
+	Query string `json:"query"`
+}
+
+func (s *Server) handleDetectDomain(req Request) Response {
+	var p detectDomainParams
+	if err := json.Unmarshal(req.Params, &p); err != nil {
+		return errorResponse(req.ID, CodeInvalidParams, err.Error())
+	}
+
+	return resultResponse(req.ID, map[string]string{"domain": search.DetectDomain(p.Query)})
+}
+
+// toolSchema describes the "search" tool in the shape MCP clients expect
+// from a tools/list response, so they can auto-discover how to call it.
+var toolSchema = map[string]interface{}{
+	"name":        "search",
+	"description": "Search the QuantPro quantitative trading knowledge base (strategies, indicators, risk management, data sources, anti-patterns).",
+	"inputSchema": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"query": map[string]interface{}{
+				"type":        "string",
+				"description": "Free-text search query",
+			},
+			"domain": map[string]interface{}{
+				"type":        "string",
+				"description": "Domain to search, or \"all\" to fuse every domain. Auto-detected when omitted.",
+				"enum":        []string{"strategy", "indicator", "risk", "data", "anti-pattern", "all"},
+			},
+			"max_results": map[string]interface{}{
+				"type":        "integer",
+				"description": "Maximum number of results to return",
+				"default":     3,
+			},
+		},
+		"required": []string{"query"},
+	},
+}
+
+func (s *Server) handleToolsList(req Request) Response {
+	return resultResponse(req.ID, map[string]interface{}{
+		"tools": []interface{}{toolSchema},
+	})
+}
+
+// toolCallParams is the shape of a tools/call request: the tool name
+// advertised by tools/list, plus its arguments in that tool's own params
+// shape (searchParams, for "search").
+type toolCallParams struct {
+	Name      string          `json:"name"`
+	Arguments json.RawMessage `json:"arguments"`
+}
+
+// handleToolsCall routes a tools/call invocation to the matching method
+// handler, so a client that auto-discovered "search" from tools/list can
+// actually invoke it. Without this, tools/call falls through Handle's
+// default case to MethodNotFound.
+func (s *Server) handleToolsCall(req Request) Response {
+	var p toolCallParams
+	if err := json.Unmarshal(req.Params, &p); err != nil {
+		return errorResponse(req.ID, CodeInvalidParams, err.Error())
+	}
+
+	switch p.Name {
+	case "search":
+		resp := s.handleSearch(Request{ID: req.ID, Method: p.Name, Params: p.Arguments})
+		if resp.Error != nil {
+			return resp
+		}
+		return resultResponse(req.ID, toolCallResult(resp.Result))
+	default:
+		return errorResponse(req.ID, CodeInvalidParams, fmt.Sprintf("unknown tool: %s", p.Name))
+	}
+}
+
+// toolCallResult wraps a handler's result in the content-block shape MCP
+// clients expect from tools/call.
+func toolCallResult(result interface{}) map[string]interface{} {
+	body, err := json.Marshal(result)
+	if err != nil {
+		body = []byte(err.Error())
+	}
+
+	return map[string]interface{}{
+		"content": []map[string]interface{}{
+			{"type": "text", "text": string(body)},
+		},
+	}
+}
@@ -0,0 +1,123 @@
+package mcp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ServeStdio runs the server over stdio using MCP's Content-Length
+// framing (the same header-delimited framing LSP uses):
+//
+//	Content-Length: <n>\r\n
+//	\r\n
+//	<n bytes of JSON-RPC request>
+//
+// It blocks until r is exhausted or a read/write error occurs.
+func (s *Server) ServeStdio(r io.Reader, w io.Writer) error {
+	reader := bufio.NewReader(r)
+
+	for {
+		length, err := readContentLength(reader)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		body := make([]byte, length)
+		if _, err := io.ReadFull(reader, body); err != nil {
+			return fmt.Errorf("failed to read message body: %w", err)
+		}
+
+		var req Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			// A malformed body can't tell us whether it was a notification,
+			// so per the JSON-RPC spec it still gets an error response.
+			if err := writeFramed(w, errorResponse(nil, CodeParseError, err.Error())); err != nil {
+				return err
+			}
+			continue
+		}
+
+		resp := s.Handle(req)
+
+		// Notifications (requests with no id) must not receive a response.
+		if len(req.ID) == 0 {
+			continue
+		}
+
+		if err := writeFramed(w, resp); err != nil {
+			return err
+		}
+	}
+}
+
+// readContentLength consumes header lines up to and including the blank
+// line that terminates them, returning the declared body length.
+func readContentLength(r *bufio.Reader) (int, error) {
+	length := -1
+
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return 0, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		if line == "" {
+			break
+		}
+
+		name, value, ok := strings.Cut(line, ":")
+		if ok && strings.EqualFold(strings.TrimSpace(name), "Content-Length") {
+			n, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return 0, fmt.Errorf("invalid Content-Length: %w", err)
+			}
+			length = n
+		}
+	}
+
+	if length < 0 {
+		return 0, fmt.Errorf("missing Content-Length header")
+	}
+	return length, nil
+}
+
+func writeFramed(w io.Writer, resp Response) error {
+	body, err := json.Marshal(resp)
+	if err != nil {
+		return fmt.Errorf("failed to marshal response: %w", err)
+	}
+
+	if _, err := fmt.Fprintf(w, "Content-Length: %d\r\n\r\n", len(body)); err != nil {
+		return err
+	}
+	_, err = w.Write(body)
+	return err
+}
+
+// ServeHTTP implements http.Handler, accepting a JSON-RPC request body
+// and writing the JSON-RPC response with no additional framing.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req Request
+	var resp Response
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		resp = errorResponse(nil, CodeParseError, err.Error())
+	} else {
+		resp = s.Handle(req)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if resp.Error != nil {
+		w.WriteHeader(http.StatusOK) // JSON-RPC errors still ride a 200
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
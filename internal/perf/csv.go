@@ -0,0 +1,171 @@
+package perf
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Trade is a single fill from a per-trade log.
+type Trade struct {
+	Time   time.Time
+	Symbol string
+	Side   string // "buy" or "sell"
+	Qty    float64
+	Price  float64
+	Fee    float64
+}
+
+// loadCSV reads a CSV into a lowercased header and its raw string rows,
+// mirroring internal/search's loadCSV so the two commands parse CSVs the
+// same way.
+func loadCSV(path string) (header []string, rows [][]string, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(records) < 2 {
+		return nil, nil, fmt.Errorf("CSV file too short")
+	}
+
+	header = make([]string, len(records[0]))
+	for i, h := range records[0] {
+		header[i] = strings.ToLower(strings.TrimSpace(h))
+	}
+
+	return header, records[1:], nil
+}
+
+// isTradeLog reports whether header describes a per-trade fill log
+// rather than a periodic equity/returns series.
+func isTradeLog(header []string) bool {
+	required := []string{"time", "symbol", "side", "qty", "price"}
+	for _, col := range required {
+		if colIndex(header, col) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func colIndex(header []string, name string) int {
+	for i, h := range header {
+		if h == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func parseTrades(header []string, rows [][]string) ([]Trade, error) {
+	timeIdx := colIndex(header, "time")
+	symbolIdx := colIndex(header, "symbol")
+	sideIdx := colIndex(header, "side")
+	qtyIdx := colIndex(header, "qty")
+	priceIdx := colIndex(header, "price")
+	feeIdx := colIndex(header, "fee") // optional
+
+	trades := make([]Trade, 0, len(rows))
+	for i, row := range rows {
+		t, err := parseTime(row[timeIdx])
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", i+2, err)
+		}
+		qty, err := strconv.ParseFloat(strings.TrimSpace(row[qtyIdx]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: invalid qty: %w", i+2, err)
+		}
+		price, err := strconv.ParseFloat(strings.TrimSpace(row[priceIdx]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: invalid price: %w", i+2, err)
+		}
+		var fee float64
+		if feeIdx >= 0 && feeIdx < len(row) && strings.TrimSpace(row[feeIdx]) != "" {
+			fee, err = strconv.ParseFloat(strings.TrimSpace(row[feeIdx]), 64)
+			if err != nil {
+				return nil, fmt.Errorf("row %d: invalid fee: %w", i+2, err)
+			}
+		}
+
+		trades = append(trades, Trade{
+			Time:   t,
+			Symbol: row[symbolIdx],
+			Side:   strings.ToLower(strings.TrimSpace(row[sideIdx])),
+			Qty:    qty,
+			Price:  price,
+			Fee:    fee,
+		})
+	}
+
+	return trades, nil
+}
+
+// parseReturns derives a per-period return series from either a "return"
+// column (used as-is) or an "equity" column (converted to period-over-
+// period percentage change).
+func parseReturns(header []string, rows [][]string) ([]float64, error) {
+	if idx := colIndex(header, "return"); idx >= 0 {
+		returns := make([]float64, 0, len(rows))
+		for i, row := range rows {
+			r, err := strconv.ParseFloat(strings.TrimSpace(row[idx]), 64)
+			if err != nil {
+				return nil, fmt.Errorf("row %d: invalid return: %w", i+2, err)
+			}
+			returns = append(returns, r)
+		}
+		return returns, nil
+	}
+
+	idx := colIndex(header, "equity")
+	if idx < 0 {
+		return nil, fmt.Errorf("CSV has neither a trade log (time,symbol,side,qty,price) nor an equity/return series (time,equity or time,return) header")
+	}
+
+	equity := make([]float64, 0, len(rows))
+	for i, row := range rows {
+		v, err := strconv.ParseFloat(strings.TrimSpace(row[idx]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: invalid equity: %w", i+2, err)
+		}
+		equity = append(equity, v)
+	}
+
+	if len(equity) < 2 {
+		return nil, fmt.Errorf("need at least 2 equity points to derive returns")
+	}
+
+	returns := make([]float64, 0, len(equity)-1)
+	for i := 1; i < len(equity); i++ {
+		if equity[i-1] == 0 {
+			return nil, fmt.Errorf("equity touches zero at row %d; cannot compute a return", i+1)
+		}
+		returns = append(returns, equity[i]/equity[i-1]-1)
+	}
+	return returns, nil
+}
+
+var timeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+func parseTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized time format: %q", s)
+}
@@ -0,0 +1,71 @@
+// Package perf computes trade-performance statistics for quantpro
+// evaluate: the metrics the knowledge base talks about (Sharpe, Sortino,
+// drawdown, ...) but never itself calculates.
+package perf
+
+// Stats holds the trade-performance metrics reported by quantpro
+// evaluate. All ratio fields are expressed as fractions (0.2 == 20%),
+// not percentages.
+type Stats struct {
+	TotalReturn         float64
+	AnnualizedReturn    float64
+	Sharpe              float64
+	Sortino             float64
+	Calmar              float64
+	MaxDrawdown         float64 // negative, e.g. -0.18 for an 18% drawdown
+	MaxDrawdownDuration int     // periods from the peak to the deepest point in the drawdown (peak-to-trough)
+	WinRate             float64
+	ProfitFactor        float64
+	AvgWin              float64
+	AvgLoss             float64 // negative
+	Expectancy          float64
+	// Turnover is the gross notional traded (sum of qty*price across all
+	// fills). It is only meaningful for trade-log input; an equity/returns
+	// series carries no quantity information, so it is left at zero.
+	Turnover   float64
+	TradeCount int
+}
+
+// Options configures an evaluation run.
+type Options struct {
+	// PeriodsPerYear annualizes Sharpe/Sortino/return figures: 252 for
+	// daily bars, 52 for weekly, 12 for monthly, 1 for a closed-trade
+	// series treated as its own periods.
+	PeriodsPerYear float64
+	// MAR is the minimum acceptable return used as the Sortino downside
+	// threshold.
+	MAR float64
+}
+
+// DefaultOptions assumes a daily equity/returns series and a 0% MAR.
+func DefaultOptions() Options {
+	return Options{PeriodsPerYear: 252}
+}
+
+// Evaluate loads path, auto-detects whether it holds a per-trade fill log
+// (time,symbol,side,qty,price,fee) or a periodic equity/returns series
+// (time,equity or time,return), and computes Stats accordingly.
+func Evaluate(path string, opts Options) (*Stats, error) {
+	if opts.PeriodsPerYear <= 0 {
+		opts.PeriodsPerYear = 252
+	}
+
+	header, rows, err := loadCSV(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if isTradeLog(header) {
+		trades, err := parseTrades(header, rows)
+		if err != nil {
+			return nil, err
+		}
+		return fromTrades(trades, opts)
+	}
+
+	returns, err := parseReturns(header, rows)
+	if err != nil {
+		return nil, err
+	}
+	return fromReturns(returns, opts)
+}
@@ -0,0 +1,159 @@
+package perf
+
+import "math"
+
+// computeStats derives every metric that depends only on a per-period
+// return series, shared by both the trade-log and equity/returns-series
+// input shapes. Callers fill in Turnover and TradeCount, which need the
+// original fills.
+func computeStats(returns []float64, opts Options) *Stats {
+	n := len(returns)
+	if n == 0 {
+		return &Stats{}
+	}
+
+	totalReturn := 1.0
+	for _, r := range returns {
+		totalReturn *= 1 + r
+	}
+	totalReturn -= 1
+
+	annualized := math.Pow(1+totalReturn, opts.PeriodsPerYear/float64(n)) - 1
+
+	mean := meanOf(returns)
+	sharpe := 0.0
+	if sd := stdDevOf(returns, mean); sd > 0 {
+		sharpe = mean / sd * math.Sqrt(opts.PeriodsPerYear)
+	}
+
+	sortino := 0.0
+	if dd := downsideDeviation(returns, opts.MAR); dd > 0 {
+		sortino = (mean - opts.MAR) / dd * math.Sqrt(opts.PeriodsPerYear)
+	}
+
+	maxDD, maxDDDuration := maxDrawdown(returns)
+
+	calmar := 0.0
+	if maxDD < 0 {
+		calmar = annualized / math.Abs(maxDD)
+	}
+
+	winRate, profitFactor, avgWin, avgLoss, expectancy := tradeQuality(returns)
+
+	return &Stats{
+		TotalReturn:         totalReturn,
+		AnnualizedReturn:    annualized,
+		Sharpe:              sharpe,
+		Sortino:             sortino,
+		Calmar:              calmar,
+		MaxDrawdown:         maxDD,
+		MaxDrawdownDuration: maxDDDuration,
+		WinRate:             winRate,
+		ProfitFactor:        profitFactor,
+		AvgWin:              avgWin,
+		AvgLoss:             avgLoss,
+		Expectancy:          expectancy,
+	}
+}
+
+func meanOf(xs []float64) float64 {
+	sum := 0.0
+	for _, x := range xs {
+		sum += x
+	}
+	return sum / float64(len(xs))
+}
+
+func stdDevOf(xs []float64, mean float64) float64 {
+	if len(xs) < 2 {
+		return 0
+	}
+	sumSq := 0.0
+	for _, x := range xs {
+		d := x - mean
+		sumSq += d * d
+	}
+	return math.Sqrt(sumSq / float64(len(xs)-1))
+}
+
+// downsideDeviation is the standard deviation of returns that fall below
+// mar, using all periods as the denominator (not just the shortfall
+// count) so a strategy with few bad periods isn't penalized as heavily
+// as one with many.
+func downsideDeviation(returns []float64, mar float64) float64 {
+	sumSq := 0.0
+	for _, r := range returns {
+		if shortfall := r - mar; shortfall < 0 {
+			sumSq += shortfall * shortfall
+		}
+	}
+	return math.Sqrt(sumSq / float64(len(returns)))
+}
+
+// maxDrawdown walks the compounded equity curve implied by returns and
+// returns the deepest peak-to-trough decline and how many periods
+// elapsed between that peak and the trough.
+func maxDrawdown(returns []float64) (depth float64, duration int) {
+	equity := 1.0
+	peak := 1.0
+	peakIdx := -1 // the starting equity, before the first period
+	maxDD := 0.0
+	maxDDDuration := 0
+
+	for i, r := range returns {
+		equity *= 1 + r
+		if equity > peak {
+			peak = equity
+			peakIdx = i
+		}
+		dd := equity/peak - 1
+		if dd < maxDD {
+			maxDD = dd
+			maxDDDuration = i - peakIdx
+		}
+	}
+
+	return maxDD, maxDDDuration
+}
+
+func tradeQuality(returns []float64) (winRate, profitFactor, avgWin, avgLoss, expectancy float64) {
+	var wins, losses []float64
+	for _, r := range returns {
+		if r > 0 {
+			wins = append(wins, r)
+		} else if r < 0 {
+			losses = append(losses, r)
+		}
+	}
+
+	n := len(returns)
+	winRate = float64(len(wins)) / float64(n)
+
+	grossWin, grossLoss := 0.0, 0.0
+	for _, w := range wins {
+		grossWin += w
+	}
+	for _, l := range losses {
+		grossLoss += l
+	}
+
+	if len(wins) > 0 {
+		avgWin = grossWin / float64(len(wins))
+	}
+	if len(losses) > 0 {
+		avgLoss = grossLoss / float64(len(losses))
+	}
+	if grossLoss < 0 {
+		profitFactor = grossWin / math.Abs(grossLoss)
+	}
+
+	lossRate := float64(len(losses)) / float64(n)
+	expectancy = winRate*avgWin + lossRate*avgLoss
+	return
+}
+
+func fromReturns(returns []float64, opts Options) (*Stats, error) {
+	stats := computeStats(returns, opts)
+	stats.TradeCount = len(returns)
+	return stats, nil
+}
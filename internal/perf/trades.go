@@ -0,0 +1,116 @@
+package perf
+
+import (
+	"math"
+	"sort"
+)
+
+// position tracks a symbol's open quantity and average cost so closing
+// fills can be matched against it.
+type position struct {
+	qty     float64 // signed: positive long, negative short
+	avgCost float64
+}
+
+// closedLot is one realized close produced by matchLots, expressed as a
+// percentage return so it composes with computeStats the same way a
+// periodic return does.
+type closedLot struct {
+	pnl       float64
+	costBasis float64
+}
+
+func (c closedLot) ret() float64 {
+	if c.costBasis == 0 {
+		return 0
+	}
+	return c.pnl / c.costBasis
+}
+
+// matchLots realizes PnL from a time-ordered fill stream using
+// average-cost lot matching: fills that add to a position blend into its
+// average cost, fills that reduce or flip it realize PnL against that
+// average cost. Fees are charged against the closing fill.
+func matchLots(trades []Trade) []closedLot {
+	sorted := make([]Trade, len(trades))
+	copy(sorted, trades)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Time.Before(sorted[j].Time) })
+
+	positions := make(map[string]*position)
+	var closes []closedLot
+
+	for _, t := range sorted {
+		signedQty := t.Qty
+		if t.Side == "sell" {
+			signedQty = -t.Qty
+		}
+
+		pos, ok := positions[t.Symbol]
+		if !ok {
+			pos = &position{}
+			positions[t.Symbol] = pos
+		}
+
+		sameDirection := pos.qty == 0 || sameSign(pos.qty, signedQty)
+		if sameDirection {
+			totalQty := math.Abs(pos.qty) + math.Abs(signedQty)
+			pos.avgCost = (pos.avgCost*math.Abs(pos.qty) + t.Price*math.Abs(signedQty)) / totalQty
+			pos.qty += signedQty
+			continue
+		}
+
+		closingQty := math.Min(math.Abs(signedQty), math.Abs(pos.qty))
+		var pnlPerUnit float64
+		if pos.qty > 0 {
+			pnlPerUnit = t.Price - pos.avgCost // closing a long by selling
+		} else {
+			pnlPerUnit = pos.avgCost - t.Price // closing a short by buying
+		}
+
+		closes = append(closes, closedLot{
+			pnl:       pnlPerUnit*closingQty - t.Fee,
+			costBasis: pos.avgCost * closingQty,
+		})
+
+		remaining := math.Abs(signedQty) - closingQty
+		if pos.qty > 0 {
+			pos.qty -= closingQty
+		} else {
+			pos.qty += closingQty
+		}
+		if remaining > 0 {
+			// The fill over-closed the position and flips it to the
+			// opposite side at the fill price.
+			if signedQty > 0 {
+				pos.qty = remaining
+			} else {
+				pos.qty = -remaining
+			}
+			pos.avgCost = t.Price
+		}
+	}
+
+	return closes
+}
+
+func sameSign(a, b float64) bool {
+	return (a > 0 && b > 0) || (a < 0 && b < 0)
+}
+
+func fromTrades(trades []Trade, opts Options) (*Stats, error) {
+	closes := matchLots(trades)
+
+	returns := make([]float64, len(closes))
+	for i, c := range closes {
+		returns[i] = c.ret()
+	}
+
+	stats := computeStats(returns, opts)
+	stats.TradeCount = len(trades)
+
+	for _, t := range trades {
+		stats.Turnover += math.Abs(t.Qty * t.Price)
+	}
+
+	return stats, nil
+}
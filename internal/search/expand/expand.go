@@ -0,0 +1,104 @@
+package expand
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/0xboji/quant-trading-skill/internal/bm25"
+)
+
+// downWeight is the relative weight applied to a term contributed by
+// synonym expansion or stemming, so the literal query terms still
+// dominate a document's score.
+const downWeight = 0.7
+
+// Term is a single weighted term in an expanded query.
+type Term struct {
+	Text   string
+	Weight float64
+}
+
+// Expand tokenizes query and rewrites it into a weighted OR-of-
+// alternatives: every token contributes itself at weight 1, plus its
+// synonyms from the Map (and, if stem is true, a simple verb-form stem)
+// at downWeight. Terms are deduplicated, keeping the highest weight seen
+// for each one, and returned sorted by text for deterministic --explain
+// output.
+//
+// A stemmed term only matches corpus documents that were indexed through
+// StemmedShadow, which injects the same stems at index time; without
+// that, stem should stay false, since a stemmed term that appears in no
+// document just scores zero.
+func Expand(query string, synonyms Map, stem bool) []Term {
+	weights := make(map[string]float64)
+
+	upsert := func(text string, weight float64) {
+		if existing, ok := weights[text]; !ok || weight > existing {
+			weights[text] = weight
+		}
+	}
+
+	for _, tok := range bm25.Tokenize(query) {
+		upsert(tok, 1.0)
+
+		if stem {
+			if stemmed := stemVerb(tok); stemmed != tok {
+				upsert(stemmed, downWeight)
+			}
+		}
+	}
+
+	// Synonym lookups run against minLenTokens rather than bm25.Tokenize,
+	// since BM25's 3-char floor would otherwise drop 2-char trading
+	// abbreviations (MM, L2, TP, SL, DD) before the map is ever consulted.
+	for _, tok := range minLenTokens(query) {
+		for _, syn := range synonyms[tok] {
+			upsert(syn, downWeight)
+		}
+	}
+
+	terms := make([]Term, 0, len(weights))
+	for text, weight := range weights {
+		terms = append(terms, Term{Text: text, Weight: weight})
+	}
+	sort.Slice(terms, func(i, j int) bool { return terms[i].Text < terms[j].Text })
+
+	return terms
+}
+
+// stemVerb strips common verb-form suffixes (trading -> trad, hedged ->
+// hedg) so a query using one verb form also matches documents using
+// another. It's intentionally simple (suffix stripping, not a full
+// Porter stemmer) since the corpus is small and domain-specific.
+func stemVerb(tok string) string {
+	switch {
+	case strings.HasSuffix(tok, "ing") && len(tok) > 5:
+		return strings.TrimSuffix(tok, "ing")
+	case strings.HasSuffix(tok, "ed") && len(tok) > 4:
+		return strings.TrimSuffix(tok, "ed")
+	default:
+		return tok
+	}
+}
+
+// StemmedShadow returns the stem of every token in text that stemVerb
+// actually changes, space-joined. Search indexes a document's own text
+// plus its StemmedShadow, so a stemmed query term from Expand(stem=true)
+// has something in the corpus to match instead of always scoring zero.
+func StemmedShadow(text string) string {
+	tokens := bm25.Tokenize(text)
+	stems := make([]string, 0, len(tokens))
+	for _, tok := range tokens {
+		if stemmed := stemVerb(tok); stemmed != tok {
+			stems = append(stems, stemmed)
+		}
+	}
+	return strings.Join(stems, " ")
+}
+
+// minLenTokens tokenizes query the same way bm25.Tokenize does, but
+// without its 3-char floor, so single abbreviations like "TP" or "L2"
+// still reach the synonym map.
+func minLenTokens(query string) []string {
+	return bm25.TokenizeMinLen(query, 1)
+}
@@ -0,0 +1,35 @@
+package expand
+
+import "testing"
+
+// TestExpandShortAbbreviations guards against bm25.Tokenize's 3-char
+// floor swallowing 2-char trading abbreviations before the synonym map
+// is consulted: a query of just "TP" must still expand to "take"/
+// "profit", not return zero terms.
+func TestExpandShortAbbreviations(t *testing.T) {
+	cases := []struct {
+		query string
+		want  []string
+	}{
+		{"TP", []string{"profit", "take"}},
+		{"SL", []string{"loss", "stop"}},
+		{"MM", []string{"making", "market"}},
+		{"L2", []string{"book", "order"}},
+		{"DD", []string{"drawdown"}},
+	}
+
+	for _, c := range cases {
+		terms := Expand(c.query, DefaultMap, false)
+
+		got := make(map[string]bool, len(terms))
+		for _, term := range terms {
+			got[term.Text] = true
+		}
+
+		for _, want := range c.want {
+			if !got[want] {
+				t.Errorf("Expand(%q) = %v, want it to contain %q", c.query, terms, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,92 @@
+// Package expand rewrites a search query into a weighted OR-of-
+// alternatives before BM25 scoring: each original token keeps its full
+// weight, and its trading-abbreviation synonyms (and, optionally, a
+// simple verb-form stem) are folded in at a lower weight so expansion
+// improves recall without drowning out the literal match.
+package expand
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Map is a token -> expansions lookup, expanded bidirectionally from
+// configured pairs so a query using either side of a pair matches
+// documents written with the other.
+type Map map[string][]string
+
+// DefaultMap is the built-in trading synonym map, used when no
+// data/synonyms.yaml is found alongside the CSVs.
+var DefaultMap = buildMap([][2]string{
+	{"ofi", "order flow imbalance"},
+	{"mm", "market making"},
+	{"l2", "order book"},
+	{"ema", "exponential moving average"},
+	{"tp", "take profit"},
+	{"sl", "stop loss"},
+	{"dd", "drawdown"},
+})
+
+// synonymFile is the on-disk shape of data/synonyms.yaml:
+//
+//	synonyms:
+//	  - abbreviation: OFI
+//	    expansion: order flow imbalance
+type synonymFile struct {
+	Synonyms []struct {
+		Abbreviation string `yaml:"abbreviation"`
+		Expansion    string `yaml:"expansion"`
+	} `yaml:"synonyms"`
+}
+
+// LoadMap reads a synonym map from a YAML file shaped like
+// data/synonyms.yaml, so the map can be extended without recompiling.
+func LoadMap(path string) (Map, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var sf synonymFile
+	if err := yaml.Unmarshal(data, &sf); err != nil {
+		return nil, fmt.Errorf("failed to parse synonym map %s: %w", path, err)
+	}
+
+	pairs := make([][2]string, 0, len(sf.Synonyms))
+	for _, s := range sf.Synonyms {
+		pairs = append(pairs, [2]string{
+			strings.ToLower(s.Abbreviation),
+			strings.ToLower(s.Expansion),
+		})
+	}
+
+	return buildMap(pairs), nil
+}
+
+// LoadMapOrDefault is LoadMap, falling back to DefaultMap when path
+// doesn't exist or fails to parse.
+func LoadMapOrDefault(path string) Map {
+	m, err := LoadMap(path)
+	if err != nil {
+		return DefaultMap
+	}
+	return m
+}
+
+// buildMap expands abbreviation/expansion pairs into a bidirectional
+// token -> expansions lookup.
+func buildMap(pairs [][2]string) Map {
+	m := make(Map)
+	for _, pair := range pairs {
+		abbr, expansion := pair[0], pair[1]
+		expansionTokens := strings.Fields(expansion)
+		m[abbr] = append(m[abbr], expansionTokens...)
+		for _, tok := range expansionTokens {
+			m[tok] = append(m[tok], abbr)
+		}
+	}
+	return m
+}
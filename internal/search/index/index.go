@@ -0,0 +1,250 @@
+// Package index provides a persistent, Bleve-backed full-text search
+// engine over the same CSV knowledge base that internal/search scores
+// with BM25. It supports phrase queries, fuzzy matching, field-scoped
+// syntax, and per-field boosts, and is selected via the CLI's
+// --engine=bleve flag as an alternative to the in-memory BM25 engine.
+package index
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/custom"
+	"github.com/blevesearch/bleve/v2/analysis/token/lowercase"
+	"github.com/blevesearch/bleve/v2/analysis/token/stop"
+	"github.com/blevesearch/bleve/v2/analysis/tokenizer/unicode"
+	_ "github.com/blevesearch/bleve/v2/config" // registers built-in analysis components (token maps, analyzers, ...)
+	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/blevesearch/bleve/v2/registry"
+
+	"github.com/0xboji/quant-trading-skill/internal/search"
+)
+
+// analyzerName identifies the custom trading-aware analyzer registered
+// with every index built by this package: lowercase -> stopword removal
+// -> synonym expansion.
+const analyzerName = "trading"
+
+const stopWordMapName = "trading_stopwords"
+const stopFilterName = "trading_stop"
+
+// fieldBoosts assigns a relevance boost to the search columns that matter
+// most for each domain. Bleve has no per-field boost on the mapping
+// itself, so Query (query.go) applies these at query-build time instead,
+// as extra field-scoped clauses boosted via query.SetBoost. Columns not
+// listed get no extra boost.
+var fieldBoosts = map[string]map[string]float64{
+	"strategy": {
+		"Strategy Name": 3,
+		"Keywords":      2,
+		"Best For":      1,
+	},
+	"indicator": {
+		"Indicator Name": 3,
+		"Keywords":       2,
+		"Best For":       1,
+	},
+	"risk": {
+		"Risk Control": 3,
+		"Keywords":     2,
+		"Best For":     1,
+	},
+	"data": {
+		"Data Type": 3,
+		"Keywords":  2,
+		"Best For":  1,
+	},
+	"anti-pattern": {
+		"Issue":    3,
+		"Keywords": 2,
+	},
+}
+
+// stopWords are common filler terms stripped before scoring. Trading
+// abbreviations (MA, TP, SL, ...) are deliberately excluded since they
+// carry domain meaning.
+var stopWords = []string{
+	"the", "a", "an", "for", "with", "and", "or", "of", "to", "in", "on", "is", "are", "by", "at",
+}
+
+// synonymPairs maps trading tickers/abbreviations to their expanded form.
+// Both directions are indexed so a query using either side matches
+// documents written with the other.
+var synonymPairs = [][2]string{
+	{"hft", "high frequency trading"},
+	{"ma", "moving average"},
+}
+
+func init() {
+	registry.RegisterTokenFilter(SynonymFilterName, func(_ map[string]interface{}, _ *registry.Cache) (analysis.TokenFilter, error) {
+		return NewSynonymFilter(buildSynonymMap(synonymPairs)), nil
+	})
+}
+
+// indexFileName returns the on-disk path for a domain's index.
+func indexFileName(indexDir, domain string) string {
+	return filepath.Join(indexDir, domain+".bleve")
+}
+
+// buildMapping constructs the index mapping for a domain, registering the
+// trading analyzer and mapping the domain's boosted columns (the keys of
+// fieldBoosts) with it. The boosts themselves are applied at query time
+// by boostedQuery.
+func buildMapping(domain string) (*mapping.IndexMappingImpl, error) {
+	im := bleve.NewIndexMapping()
+
+	tokens := make([]interface{}, len(stopWords))
+	for i, w := range stopWords {
+		tokens[i] = w
+	}
+	if err := im.AddCustomTokenMap(stopWordMapName, map[string]interface{}{
+		"type":   "custom",
+		"tokens": tokens,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to register stopword map: %w", err)
+	}
+
+	if err := im.AddCustomTokenFilter(stopFilterName, map[string]interface{}{
+		"type":           stop.Name,
+		"stop_token_map": stopWordMapName,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to register stopword filter: %w", err)
+	}
+
+	if err := im.AddCustomAnalyzer(analyzerName, map[string]interface{}{
+		"type":          custom.Name,
+		"tokenizer":     unicode.Name,
+		"token_filters": []string{lowercase.Name, stopFilterName, SynonymFilterName},
+	}); err != nil {
+		return nil, fmt.Errorf("failed to register trading analyzer: %w", err)
+	}
+	im.DefaultAnalyzer = analyzerName
+
+	docMapping := bleve.NewDocumentMapping()
+	for field := range fieldBoosts[domain] {
+		fm := bleve.NewTextFieldMapping()
+		fm.Analyzer = analyzerName
+		docMapping.AddFieldMappingsAt(field, fm)
+	}
+	im.DefaultMapping = docMapping
+
+	return im, nil
+}
+
+// BuildOptions configures an index build.
+type BuildOptions struct {
+	DataDir  string
+	IndexDir string
+	// Domain restricts the build to a single domain; empty builds every
+	// domain in search.DomainConfigs.
+	Domain string
+}
+
+// Build ingests the CSVs for the requested domain(s) and writes a fresh
+// Bleve index per domain under opts.IndexDir.
+func Build(opts BuildOptions) error {
+	domains, err := domainsToBuild(opts.Domain)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(opts.IndexDir, 0755); err != nil {
+		return fmt.Errorf("failed to create index directory: %w", err)
+	}
+
+	for _, domain := range domains {
+		if err := buildDomain(opts.DataDir, opts.IndexDir, domain); err != nil {
+			return fmt.Errorf("domain %s: %w", domain, err)
+		}
+	}
+
+	return nil
+}
+
+// Rebuild deletes any existing index for the requested domain(s) and
+// builds it again from the current CSVs.
+func Rebuild(opts BuildOptions) error {
+	domains, err := domainsToBuild(opts.Domain)
+	if err != nil {
+		return err
+	}
+
+	for _, domain := range domains {
+		if err := os.RemoveAll(indexFileName(opts.IndexDir, domain)); err != nil {
+			return fmt.Errorf("domain %s: failed to remove old index: %w", domain, err)
+		}
+	}
+
+	return Build(opts)
+}
+
+func domainsToBuild(domain string) ([]string, error) {
+	if domain == "" {
+		domains := make([]string, 0, len(search.DomainConfigs))
+		for d := range search.DomainConfigs {
+			domains = append(domains, d)
+		}
+		return domains, nil
+	}
+
+	if _, ok := search.DomainConfigs[domain]; !ok {
+		return nil, fmt.Errorf("unknown domain: %s", domain)
+	}
+	return []string{domain}, nil
+}
+
+func buildDomain(dataDir, indexDir, domain string) error {
+	rows, config, err := search.LoadDomainData(dataDir, domain)
+	if err != nil {
+		return err
+	}
+
+	im, err := buildMapping(domain)
+	if err != nil {
+		return err
+	}
+
+	path := indexFileName(indexDir, domain)
+	_ = os.RemoveAll(path)
+
+	idx, err := bleve.New(path, im)
+	if err != nil {
+		return fmt.Errorf("failed to create index: %w", err)
+	}
+	defer idx.Close()
+
+	batch := idx.NewBatch()
+	for i, row := range rows {
+		doc := make(map[string]interface{}, len(config.OutputCols))
+		for _, col := range config.OutputCols {
+			if val, ok := row[col]; ok {
+				doc[col] = val
+			}
+		}
+		docID := fmt.Sprintf("%s-%d", domain, i)
+		if err := batch.Index(docID, doc); err != nil {
+			return fmt.Errorf("failed to index row %d: %w", i, err)
+		}
+	}
+
+	return idx.Batch(batch)
+}
+
+// buildSynonymMap expands the configured pairs into a token -> expansions
+// lookup used by SynonymFilter at index time.
+func buildSynonymMap(pairs [][2]string) map[string][]string {
+	m := make(map[string][]string)
+	for _, pair := range pairs {
+		abbr, expansion := pair[0], pair[1]
+		expansionTokens := strings.Fields(expansion)
+		m[abbr] = append(m[abbr], expansionTokens...)
+		for _, tok := range expansionTokens {
+			m[tok] = append(m[tok], abbr)
+		}
+	}
+	return m
+}
@@ -0,0 +1,85 @@
+package index
+
+import (
+	"fmt"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/search/query"
+
+	"github.com/0xboji/quant-trading-skill/internal/search"
+)
+
+// Query runs queryStr against the persisted index for domain and returns
+// results shaped like search.Result, so callers (and printResults) can
+// treat the BM25 and Bleve engines interchangeably.
+//
+// queryStr supports Bleve's query string syntax: phrases ("order flow
+// imbalance"), fuzzy matches (kalmn~1), and field-scoped terms
+// (Category:HFT).
+func Query(indexDir, domain, queryStr string, maxResults int) (*search.Result, error) {
+	config, ok := search.DomainConfigs[domain]
+	if !ok {
+		return nil, fmt.Errorf("unknown domain: %s", domain)
+	}
+
+	idx, err := bleve.Open(indexFileName(indexDir, domain))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open index for domain %s (run `quantpro index build`?): %w", domain, err)
+	}
+	defer idx.Close()
+
+	q := boostedQuery(domain, queryStr)
+	req := bleve.NewSearchRequestOptions(q, maxResults, 0, false)
+	req.Fields = config.OutputCols
+
+	searchResult, err := idx.Search(req)
+	if err != nil {
+		return nil, fmt.Errorf("search failed: %w", err)
+	}
+
+	results := make([]map[string]string, 0, len(searchResult.Hits))
+	for _, hit := range searchResult.Hits {
+		row := make(map[string]string, len(config.OutputCols))
+		for _, col := range config.OutputCols {
+			if val, ok := hit.Fields[col]; ok {
+				if s, ok := val.(string); ok {
+					row[col] = s
+				}
+			}
+		}
+		results = append(results, row)
+	}
+
+	return &search.Result{
+		Domain:  domain,
+		Query:   queryStr,
+		File:    config.File,
+		Count:   len(results),
+		Results: results,
+	}, nil
+}
+
+// boostedQuery rewrites queryStr into the unscoped query-string query
+// plus one match clause per entry in fieldBoosts[domain], scoped to that
+// field and boosted via query.SetBoost. Bleve has no per-field boost at
+// mapping time, so this is where fieldBoosts actually takes effect. The
+// field clauses match queryStr's analyzed terms; phrase and fuzzy syntax
+// applies only to the unscoped query.
+func boostedQuery(domain, queryStr string) query.Query {
+	base := bleve.NewQueryStringQuery(queryStr)
+
+	boosts := fieldBoosts[domain]
+	if len(boosts) == 0 {
+		return base
+	}
+
+	disjuncts := []query.Query{base}
+	for field, boost := range boosts {
+		fq := bleve.NewMatchQuery(queryStr)
+		fq.SetField(field)
+		fq.SetBoost(boost)
+		disjuncts = append(disjuncts, fq)
+	}
+
+	return bleve.NewDisjunctionQuery(disjuncts...)
+}
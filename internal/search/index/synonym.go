@@ -0,0 +1,39 @@
+package index
+
+import "github.com/blevesearch/bleve/v2/analysis"
+
+// SynonymFilterName is the token filter name this package registers with
+// Bleve's component registry, referenced by name from buildMapping.
+const SynonymFilterName = "trading_synonym"
+
+// SynonymFilter expands trading tickers/abbreviations into additional
+// tokens at the same position as the token that triggered them, so a
+// query using either side of a pair (HFT / "high frequency trading",
+// MA / "moving average") matches documents indexed with the other.
+type SynonymFilter struct {
+	synonyms map[string][]string
+}
+
+// NewSynonymFilter builds a filter from a token -> expansions map. Use
+// buildSynonymMap to derive one from synonymPairs.
+func NewSynonymFilter(synonyms map[string][]string) *SynonymFilter {
+	return &SynonymFilter{synonyms: synonyms}
+}
+
+// Filter implements analysis.TokenFilter.
+func (f *SynonymFilter) Filter(input analysis.TokenStream) analysis.TokenStream {
+	out := make(analysis.TokenStream, 0, len(input))
+	for _, token := range input {
+		out = append(out, token)
+		for _, expansion := range f.synonyms[string(token.Term)] {
+			out = append(out, &analysis.Token{
+				Term:     []byte(expansion),
+				Start:    token.Start,
+				End:      token.End,
+				Position: token.Position,
+				Type:     analysis.AlphaNumeric,
+			})
+		}
+	}
+	return out
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/0xboji/quant-trading-skill/internal/bm25"
+	"github.com/0xboji/quant-trading-skill/internal/search/expand"
 )
 
 // Config defines search configuration for a domain
@@ -63,6 +64,48 @@ type Result struct {
 	File    string
 	Count   int
 	Results []map[string]string
+	// Domains holds the originating domain for each entry in Results. It
+	// is only populated by SearchAll, whose Results mix rows from every
+	// domain; for single-domain Search it is nil and Domain applies to
+	// every row.
+	Domains []string
+	// Explain holds the expanded query terms and their IDF contribution,
+	// populated by Search for the --explain flag. Nil for SearchAll.
+	Explain *Explain
+}
+
+// Explain is the --explain diagnostic: how a query was rewritten by
+// internal/search/expand before BM25 scoring.
+type Explain struct {
+	Terms []ExplainTerm
+}
+
+// ExplainTerm is one expanded query term's contribution: its synonym/
+// stem weight and the IDF the corpus assigned it.
+type ExplainTerm struct {
+	Text   string
+	Weight float64
+	IDF    float64
+}
+
+// primaryFieldNames holds the column each domain uses to identify a row
+// to a human (and, via PrimaryField, to other packages that tag results).
+var primaryFieldNames = map[string]string{
+	"strategy":     "Strategy Name",
+	"indicator":    "Indicator Name",
+	"risk":         "Risk Control",
+	"data":         "Data Type",
+	"anti-pattern": "Issue",
+}
+
+// PrimaryField returns the human-identifying value of a result row for
+// its domain, e.g. the strategy name or indicator name.
+func PrimaryField(domain string, row map[string]string) string {
+	col, ok := primaryFieldNames[domain]
+	if !ok {
+		return "Unknown"
+	}
+	return row[col]
 }
 
 // DetectDomain auto-detects the most relevant domain from query
@@ -96,26 +139,38 @@ func DetectDomain(query string) string {
 	return bestDomain
 }
 
+// LoadDomainData loads the raw CSV rows and config for a domain. It is
+// exported so other engines (e.g. internal/search/index) can build their
+// own document sets from the same source data without re-deriving the
+// domain-to-file mapping.
+func LoadDomainData(dataDir, domain string) ([]map[string]string, Config, error) {
+	config, ok := DomainConfigs[domain]
+	if !ok {
+		return nil, Config{}, fmt.Errorf("unknown domain: %s", domain)
+	}
+
+	data, err := loadCSV(filepath.Join(dataDir, config.File))
+	if err != nil {
+		return nil, Config{}, err
+	}
+
+	return data, config, nil
+}
+
 // Search performs BM25 search on specified domain
 func Search(dataDir, query, domain string, maxResults int) (*Result, error) {
 	if domain == "" {
 		domain = DetectDomain(query)
 	}
 
-	config, ok := DomainConfigs[domain]
-	if !ok {
-		return nil, fmt.Errorf("unknown domain: %s", domain)
-	}
-
-	filepath := filepath.Join(dataDir, config.File)
-
-	// Load CSV
-	data, err := loadCSV(filepath)
+	data, config, err := LoadDomainData(dataDir, domain)
 	if err != nil {
 		return nil, err
 	}
 
-	// Build documents from search columns
+	// Build documents from search columns, appending each document's
+	// StemmedShadow so a stemmed query term (from Expand's stem option)
+	// has something in the corpus to match instead of always scoring zero.
 	documents := make([]string, len(data))
 	for i, row := range data {
 		parts := make([]string, 0, len(config.SearchCols))
@@ -124,13 +179,32 @@ func Search(dataDir, query, domain string, maxResults int) (*Result, error) {
 				parts = append(parts, val)
 			}
 		}
-		documents[i] = strings.Join(parts, " ")
+		doc := strings.Join(parts, " ")
+		documents[i] = doc + " " + expand.StemmedShadow(doc)
 	}
 
 	// BM25 search
 	engine := bm25.New(1.5, 0.75)
 	engine.Fit(documents)
-	ranked := engine.Score(query)
+
+	// Expand the query into a weighted OR-of-alternatives before scoring,
+	// so trading abbreviations and verb forms widen recall without
+	// drowning out the literal terms.
+	synonyms := expand.LoadMapOrDefault(filepath.Join(dataDir, "synonyms.yaml"))
+	terms := expand.Expand(query, synonyms, true)
+
+	weights := make(map[string]float64, len(terms))
+	explainTerms := make([]ExplainTerm, 0, len(terms))
+	for _, t := range terms {
+		weights[t.Text] = t.Weight
+		explainTerms = append(explainTerms, ExplainTerm{
+			Text:   t.Text,
+			Weight: t.Weight,
+			IDF:    engine.IDF(t.Text),
+		})
+	}
+
+	ranked := engine.ScoreWeighted(weights)
 
 	// Sort by score descending
 	sort.Slice(ranked, func(i, j int) bool {
@@ -163,6 +237,107 @@ func Search(dataDir, query, domain string, maxResults int) (*Result, error) {
 		File:    config.File,
 		Count:   len(results),
 		Results: results,
+		Explain: &Explain{Terms: explainTerms},
+	}, nil
+}
+
+// rrfK is the standard Reciprocal Rank Fusion smoothing constant.
+const rrfK = 60
+
+// SearchAll scores the query against every domain and fuses the
+// per-domain BM25 rankings with Reciprocal Rank Fusion: a document at
+// rank r in domain d contributes 1/(rrfK+r) to its fused score, summed
+// across every domain it ranks in. The top maxResults rows are returned
+// across all domains, each tagged with its originating domain via
+// Result.Domains, so a single query can surface the best strategy,
+// risk-control, and data-source hits together instead of guessing -d.
+func SearchAll(dataDir, query string, maxResults int) (*Result, error) {
+	type candidate struct {
+		domain string
+		row    map[string]string
+		score  float64
+	}
+
+	var candidates []candidate
+
+	for domain, config := range DomainConfigs {
+		data, _, err := LoadDomainData(dataDir, domain)
+		if err != nil {
+			continue // domain CSV missing or unreadable; skip it
+		}
+
+		documents := make([]string, len(data))
+		for i, row := range data {
+			parts := make([]string, 0, len(config.SearchCols))
+			for _, col := range config.SearchCols {
+				if val, ok := row[col]; ok {
+					parts = append(parts, val)
+				}
+			}
+			documents[i] = strings.Join(parts, " ")
+		}
+
+		engine := bm25.New(1.5, 0.75)
+		engine.Fit(documents)
+		ranked := engine.Score(query)
+
+		sort.Slice(ranked, func(i, j int) bool {
+			return ranked[i].Score > ranked[j].Score
+		})
+
+		for rank, r := range ranked {
+			if r.Score <= 0 {
+				break
+			}
+
+			row := data[r.Index]
+			out := make(map[string]string, len(config.OutputCols))
+			for _, col := range config.OutputCols {
+				if val, ok := row[col]; ok {
+					out[col] = val
+				}
+			}
+
+			candidates = append(candidates, candidate{
+				domain: domain,
+				row:    out,
+				score:  1.0 / float64(rrfK+rank+1),
+			})
+		}
+	}
+
+	// Every domain's rank-0 hit gets the identical RRF score 1/(rrfK+1),
+	// and candidates are built by ranging over DomainConfigs (a map), so a
+	// score-only sort would let those ties resolve in random iteration
+	// order from run to run. Break ties by domain, then by the row's
+	// primary field, so the fused ordering is deterministic.
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].score != candidates[j].score {
+			return candidates[i].score > candidates[j].score
+		}
+		if candidates[i].domain != candidates[j].domain {
+			return candidates[i].domain < candidates[j].domain
+		}
+		return PrimaryField(candidates[i].domain, candidates[i].row) < PrimaryField(candidates[j].domain, candidates[j].row)
+	})
+
+	if len(candidates) > maxResults {
+		candidates = candidates[:maxResults]
+	}
+
+	results := make([]map[string]string, len(candidates))
+	domains := make([]string, len(candidates))
+	for i, c := range candidates {
+		results[i] = c.row
+		domains[i] = c.domain
+	}
+
+	return &Result{
+		Domain:  "all",
+		Query:   query,
+		Count:   len(results),
+		Results: results,
+		Domains: domains,
 	}, nil
 }
 